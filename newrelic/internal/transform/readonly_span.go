@@ -0,0 +1,137 @@
+// Copyright 2019 New Relic Corporation. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/api/core"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/codes"
+)
+
+// Status is the result of a span's execution, mirroring the StatusCode and
+// StatusMessage fields historically carried directly on trace.SpanData.
+type Status struct {
+	Code    codes.Code
+	Message string
+}
+
+// ReadOnlySpan describes the read-only view of a finished span that Span
+// needs in order to transform it for New Relic. It mirrors the direction
+// the OpenTelemetry SDK itself has moved in, exposing accessors rather than
+// requiring callers to hand over a mutable *trace.SpanData.
+type ReadOnlySpan interface {
+	SpanContext() core.SpanContext
+	Parent() core.SpanID
+	Name() string
+	StartTime() time.Time
+	EndTime() time.Time
+	Attributes() []core.KeyValue
+	Events() []trace.Event
+	Links() []trace.Link
+	Status() Status
+	SpanKind() apitrace.SpanKind
+	InstrumentationLibrary() instrumentation.Library
+	Resource() *resource.Resource
+	DroppedAttributes() int
+	DroppedEvents() int
+	DroppedLinks() int
+}
+
+// SpanStub is a declarative, mutable representation of a finished span,
+// intended for building test fixtures without poking trace.SpanData
+// literals directly. Call Snapshot to obtain the immutable ReadOnlySpan
+// view that Span consumes.
+type SpanStub struct {
+	SpanContext            core.SpanContext
+	ParentSpanID           core.SpanID
+	SpanKind               apitrace.SpanKind
+	Name                   string
+	StartTime              time.Time
+	EndTime                time.Time
+	Attributes             []core.KeyValue
+	MessageEvents          []trace.Event
+	Links                  []trace.Link
+	Status                 Status
+	DroppedAttributeCount  int
+	DroppedEventCount      int
+	DroppedLinkCount       int
+	Resource               *resource.Resource
+	InstrumentationLibrary instrumentation.Library
+}
+
+// Snapshot returns a ReadOnlySpan view of the stub, suitable for passing to
+// Span. The stub is copied, so later assignments to its fields do not affect
+// the returned value; its slices and Resource are shared, not copied.
+func (s SpanStub) Snapshot() ReadOnlySpan {
+	return spanSnapshot{stub: s}
+}
+
+// spanSnapshot wraps a SpanStub rather than converting it directly so that
+// its accessor methods don't collide with SpanStub's identically-named
+// fields.
+type spanSnapshot struct {
+	stub SpanStub
+}
+
+func (s spanSnapshot) SpanContext() core.SpanContext { return s.stub.SpanContext }
+func (s spanSnapshot) Parent() core.SpanID           { return s.stub.ParentSpanID }
+func (s spanSnapshot) Name() string                  { return s.stub.Name }
+func (s spanSnapshot) StartTime() time.Time          { return s.stub.StartTime }
+func (s spanSnapshot) EndTime() time.Time            { return s.stub.EndTime }
+func (s spanSnapshot) Attributes() []core.KeyValue   { return s.stub.Attributes }
+func (s spanSnapshot) Events() []trace.Event         { return s.stub.MessageEvents }
+func (s spanSnapshot) Links() []trace.Link           { return s.stub.Links }
+func (s spanSnapshot) Status() Status                { return s.stub.Status }
+func (s spanSnapshot) SpanKind() apitrace.SpanKind   { return s.stub.SpanKind }
+func (s spanSnapshot) InstrumentationLibrary() instrumentation.Library {
+	return s.stub.InstrumentationLibrary
+}
+func (s spanSnapshot) Resource() *resource.Resource { return s.stub.Resource }
+func (s spanSnapshot) DroppedAttributes() int       { return s.stub.DroppedAttributeCount }
+func (s spanSnapshot) DroppedEvents() int           { return s.stub.DroppedEventCount }
+func (s spanSnapshot) DroppedLinks() int            { return s.stub.DroppedLinkCount }
+
+// spanDataAdapter adapts a *trace.SpanData, as produced by the OpenTelemetry
+// SDK, to the ReadOnlySpan interface.
+//
+// Deprecated: pass a ReadOnlySpan (for example via SpanStub.Snapshot) to
+// Span directly. This adapter exists only to ease the migration away from
+// *trace.SpanData and will be removed in a future release.
+type spanDataAdapter struct {
+	sd *trace.SpanData
+}
+
+// SpanData adapts a *trace.SpanData to a ReadOnlySpan.
+//
+// Deprecated: build a ReadOnlySpan directly (for example via
+// SpanStub.Snapshot) instead. This exists only for the deprecation window
+// while callers migrate off of *trace.SpanData.
+func SpanData(sd *trace.SpanData) ReadOnlySpan {
+	return spanDataAdapter{sd: sd}
+}
+
+func (a spanDataAdapter) SpanContext() core.SpanContext { return a.sd.SpanContext }
+func (a spanDataAdapter) Parent() core.SpanID           { return a.sd.ParentSpanID }
+func (a spanDataAdapter) Name() string                  { return a.sd.Name }
+func (a spanDataAdapter) StartTime() time.Time          { return a.sd.StartTime }
+func (a spanDataAdapter) EndTime() time.Time            { return a.sd.EndTime }
+func (a spanDataAdapter) Attributes() []core.KeyValue   { return a.sd.Attributes }
+func (a spanDataAdapter) Events() []trace.Event         { return a.sd.MessageEvents }
+func (a spanDataAdapter) Links() []trace.Link           { return a.sd.Links }
+func (a spanDataAdapter) Status() Status {
+	return Status{Code: a.sd.StatusCode, Message: a.sd.StatusMessage}
+}
+func (a spanDataAdapter) SpanKind() apitrace.SpanKind { return a.sd.SpanKind }
+func (a spanDataAdapter) InstrumentationLibrary() instrumentation.Library {
+	return a.sd.InstrumentationLibrary
+}
+func (a spanDataAdapter) Resource() *resource.Resource { return a.sd.Resource }
+func (a spanDataAdapter) DroppedAttributes() int       { return a.sd.DroppedAttributeCount }
+func (a spanDataAdapter) DroppedEvents() int           { return a.sd.DroppedMessageEventCount }
+func (a spanDataAdapter) DroppedLinks() int            { return a.sd.DroppedLinkCount }
@@ -0,0 +1,230 @@
+// Copyright 2019 New Relic Corporation. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transform converts OpenTelemetry SDK span data into the span
+// representation expected by the New Relic Telemetry SDK.
+package transform
+
+import (
+	"fmt"
+
+	"github.com/newrelic/newrelic-telemetry-sdk-go/telemetry"
+	"go.opentelemetry.io/otel/api/core"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	instrumentationProviderAttrKey   = "instrumentation.provider"
+	instrumentationProviderAttrValue = "opentelemetry"
+	collectorNameAttrKey             = "collector.name"
+	collectorNameAttrValue           = "newrelic-opentelemetry-exporter"
+	errorCodeAttrKey                 = "error.code"
+	errorMessageAttrKey              = "error.message"
+	spanKindAttrKey                  = "span.kind"
+	serviceNameAttrKey               = "service.name"
+	instrumentationNameAttrKey       = "instrumentation.name"
+	instrumentationVersionAttrKey    = "instrumentation.version"
+	droppedAttributesCountAttrKey    = "otel.dropped_attributes_count"
+	droppedEventsCountAttrKey        = "otel.dropped_events_count"
+	droppedLinksCountAttrKey         = "otel.dropped_links_count"
+)
+
+// Span transforms a ReadOnlySpan, as collected by the OpenTelemetry SDK,
+// into a telemetry.Span that can be sent to New Relic. Callers still
+// holding a *trace.SpanData can obtain a ReadOnlySpan via SpanData.
+func Span(service string, span ReadOnlySpan) telemetry.Span {
+	return transformSpan(service, span, filteredResourceAttributes(span.Resource()))
+}
+
+// Spans transforms a batch of *trace.SpanData in one pass, isolating
+// failures to the span that caused them rather than aborting the whole
+// batch. It returns the successfully transformed spans alongside one error
+// per span that could not be transformed, so callers can report a
+// partial-success count instead of silently dropping malformed spans.
+//
+// Spans sharing the same Resource (the common case for spans emitted by a
+// single process) have their resource attributes computed once and reused,
+// rather than recomputed per span.
+func Spans(service string, in []*trace.SpanData) ([]telemetry.Span, []error) {
+	out := make([]telemetry.Span, 0, len(in))
+	var errs []error
+
+	resourceAttrs := make(map[*resource.Resource][]core.KeyValue)
+	for i, sd := range in {
+		if sd == nil {
+			errs = append(errs, fmt.Errorf("span %d: nil SpanData", i))
+			continue
+		}
+
+		attrs, ok := resourceAttrs[sd.Resource]
+		if !ok {
+			attrs = filteredResourceAttributes(sd.Resource)
+			resourceAttrs[sd.Resource] = attrs
+		}
+
+		out = append(out, transformSpan(service, SpanData(sd), attrs))
+	}
+	return out, errs
+}
+
+// filteredResourceAttributes returns r's attributes as core.KeyValue,
+// dropping service.name. The top-level telemetry.Span.ServiceName field is
+// set from the service argument, so the resource's service.name value is
+// not used.
+func filteredResourceAttributes(r *resource.Resource) []core.KeyValue {
+	if r == nil {
+		return nil
+	}
+	attrs := r.Attributes()
+	out := make([]core.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		if kv.Key == serviceNameAttrKey {
+			continue
+		}
+		out = append(out, kv)
+	}
+	return out
+}
+
+// transformSpan does the work of Span, taking the span's already-filtered
+// resource attributes so that Spans can share them across a batch.
+func transformSpan(service string, span ReadOnlySpan, resourceAttrs []core.KeyValue) telemetry.Span {
+	attributes := make(map[string]interface{})
+
+	attributes[instrumentationProviderAttrKey] = instrumentationProviderAttrValue
+	attributes[collectorNameAttrKey] = collectorNameAttrValue
+
+	for _, kv := range span.Attributes() {
+		setAttribute(attributes, kv)
+	}
+
+	if status := span.Status(); status.Code != codes.OK || status.Message != "" {
+		attributes[errorCodeAttrKey] = uint32(status.Code)
+		attributes[errorMessageAttrKey] = status.Message
+	}
+
+	for i, event := range span.Events() {
+		addEventAttributes(attributes, i, event)
+	}
+
+	for i, link := range span.Links() {
+		addLinkAttributes(attributes, i, link)
+	}
+
+	if kind, ok := spanKindAttrValue(span.SpanKind()); ok {
+		attributes[spanKindAttrKey] = kind
+	}
+
+	if name := span.InstrumentationLibrary().Name; name != "" {
+		attributes[instrumentationNameAttrKey] = name
+	}
+	if version := span.InstrumentationLibrary().Version; version != "" {
+		attributes[instrumentationVersionAttrKey] = version
+	}
+
+	if n := span.DroppedAttributes(); n > 0 {
+		attributes[droppedAttributesCountAttrKey] = n
+	}
+	if n := span.DroppedEvents(); n > 0 {
+		attributes[droppedEventsCountAttrKey] = n
+	}
+	if n := span.DroppedLinks(); n > 0 {
+		attributes[droppedLinksCountAttrKey] = n
+	}
+
+	for _, kv := range resourceAttrs {
+		setAttribute(attributes, kv)
+	}
+
+	var parentID string
+	if parent := span.Parent(); parent != (core.SpanID{}) {
+		parentID = parent.String()
+	}
+
+	return telemetry.Span{
+		Name:        span.Name(),
+		ID:          span.SpanContext().SpanID.String(),
+		TraceID:     span.SpanContext().TraceID.String(),
+		ParentID:    parentID,
+		Timestamp:   span.StartTime(),
+		Duration:    span.EndTime().Sub(span.StartTime()),
+		ServiceName: service,
+		Attributes:  attributes,
+	}
+}
+
+// addEventAttributes serializes a trace.Event onto attributes as a set of
+// flattened `event.<index>.*` keys, since New Relic spans have no
+// first-class concept of span events.
+func addEventAttributes(attributes map[string]interface{}, index int, event trace.Event) {
+	attributes[fmt.Sprintf("event.%d.name", index)] = event.Name
+	attributes[fmt.Sprintf("event.%d.time", index)] = event.Time
+	for _, kv := range event.Attributes {
+		attributes[fmt.Sprintf("event.%d.%s", index, kv.Key)] = attributeValue(kv)
+	}
+}
+
+// addLinkAttributes serializes a trace.Link onto attributes as a set of
+// flattened `link.<index>.*` keys, since New Relic spans have no
+// first-class concept of span links.
+func addLinkAttributes(attributes map[string]interface{}, index int, link trace.Link) {
+	attributes[fmt.Sprintf("link.%d.trace_id", index)] = link.SpanContext.TraceID.String()
+	attributes[fmt.Sprintf("link.%d.span_id", index)] = link.SpanContext.SpanID.String()
+	for _, kv := range link.Attributes {
+		attributes[fmt.Sprintf("link.%d.%s", index, kv.Key)] = attributeValue(kv)
+	}
+}
+
+// spanKindAttrValue maps an OpenTelemetry trace.SpanKind onto the string New
+// Relic expects for the span.kind attribute. The second return value is
+// false when the kind is unspecified and no attribute should be emitted.
+func spanKindAttrValue(kind apitrace.SpanKind) (string, bool) {
+	switch kind {
+	case apitrace.SpanKindInternal:
+		return "internal", true
+	case apitrace.SpanKindServer:
+		return "server", true
+	case apitrace.SpanKindClient:
+		return "client", true
+	case apitrace.SpanKindProducer:
+		return "producer", true
+	case apitrace.SpanKindConsumer:
+		return "consumer", true
+	default:
+		return "", false
+	}
+}
+
+// setAttribute converts a core.KeyValue into its Go-native value and stores
+// it under kv.Key in attributes.
+func setAttribute(attributes map[string]interface{}, kv core.KeyValue) {
+	attributes[string(kv.Key)] = attributeValue(kv)
+}
+
+// attributeValue converts a core.KeyValue's Value into the Go-native type
+// expected by the New Relic Telemetry SDK.
+func attributeValue(kv core.KeyValue) interface{} {
+	switch kv.Value.Type() {
+	case core.BOOL:
+		return kv.Value.AsBool()
+	case core.INT32:
+		return kv.Value.AsInt32()
+	case core.INT64:
+		return kv.Value.AsInt64()
+	case core.UINT32:
+		return kv.Value.AsUint32()
+	case core.UINT64:
+		return kv.Value.AsUint64()
+	case core.FLOAT32:
+		return kv.Value.AsFloat32()
+	case core.FLOAT64:
+		return kv.Value.AsFloat64()
+	case core.STRING:
+		return kv.Value.AsString()
+	default:
+		return kv.Value.Emit()
+	}
+}
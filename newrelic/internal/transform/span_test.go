@@ -10,7 +10,10 @@ import (
 
 	"github.com/newrelic/newrelic-telemetry-sdk-go/telemetry"
 	"go.opentelemetry.io/otel/api/core"
+	apitrace "go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"google.golang.org/grpc/codes"
 )
 
@@ -31,12 +34,12 @@ func TestTransformSpans(t *testing.T) {
 	now := time.Now()
 	testcases := []struct {
 		testname string
-		input    *trace.SpanData
+		input    SpanStub
 		expect   telemetry.Span
 	}{
 		{
 			testname: "basic span",
-			input: &trace.SpanData{
+			input: SpanStub{
 				SpanContext: core.SpanContext{
 					TraceID: sampleTraceID,
 					SpanID:  sampleSpanID,
@@ -60,7 +63,7 @@ func TestTransformSpans(t *testing.T) {
 		},
 		{
 			testname: "span with parent",
-			input: &trace.SpanData{
+			input: SpanStub{
 				SpanContext: core.SpanContext{
 					TraceID: sampleTraceID,
 					SpanID:  sampleSpanID,
@@ -86,16 +89,15 @@ func TestTransformSpans(t *testing.T) {
 		},
 		{
 			testname: "span with error",
-			input: &trace.SpanData{
+			input: SpanStub{
 				SpanContext: core.SpanContext{
 					TraceID: sampleTraceID,
 					SpanID:  sampleSpanID,
 				},
-				StatusCode:    codes.ResourceExhausted,
-				StatusMessage: "ResourceExhausted",
-				StartTime:     now,
-				EndTime:       now.Add(2 * time.Second),
-				Name:          "mySpan",
+				Status:    Status{Code: codes.ResourceExhausted, Message: "ResourceExhausted"},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
 			},
 			expect: telemetry.Span{
 				Name:        "mySpan",
@@ -114,7 +116,7 @@ func TestTransformSpans(t *testing.T) {
 		},
 		{
 			testname: "span with attributes",
-			input: &trace.SpanData{
+			input: SpanStub{
 				SpanContext: core.SpanContext{
 					TraceID: sampleTraceID,
 					SpanID:  sampleSpanID,
@@ -158,18 +160,385 @@ func TestTransformSpans(t *testing.T) {
 				},
 			},
 		},
+		{
+			testname: "span with events",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+				MessageEvents: []trace.Event{
+					{
+						Name: "evt1",
+						Time: now,
+						Attributes: []core.KeyValue{
+							core.Key("k1").String("v1"),
+						},
+					},
+				},
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					"event.0.name":                 "evt1",
+					"event.0.time":                 now,
+					"event.0.k1":                   "v1",
+				},
+			},
+		},
+		{
+			testname: "span with links",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+				Links: []trace.Link{
+					{
+						SpanContext: core.SpanContext{
+							TraceID: sampleTraceID,
+							SpanID:  sampleParentID,
+						},
+						Attributes: []core.KeyValue{
+							core.Key("k1").Bool(true),
+						},
+					},
+				},
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					"link.0.trace_id":              sampleTraceIDString,
+					"link.0.span_id":               sampleParentIDString,
+					"link.0.k1":                    true,
+				},
+			},
+		},
+		{
+			testname: "span with server kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindServer,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					spanKindAttrKey:                "server",
+				},
+			},
+		},
+		{
+			testname: "span with client kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindClient,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					spanKindAttrKey:                "client",
+				},
+			},
+		},
+		{
+			testname: "span with producer kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindProducer,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					spanKindAttrKey:                "producer",
+				},
+			},
+		},
+		{
+			testname: "span with consumer kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindConsumer,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					spanKindAttrKey:                "consumer",
+				},
+			},
+		},
+		{
+			testname: "span with internal kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindInternal,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					spanKindAttrKey:                "internal",
+				},
+			},
+		},
+		{
+			testname: "span with unspecified kind omits span.kind",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				SpanKind:  apitrace.SpanKindUnspecified,
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+				},
+			},
+		},
+		{
+			testname: "span with resource",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+				Resource: resource.New(
+					core.Key("service.name").String("ignoredBecausePromoted"),
+					core.Key("cloud.provider").String("aws"),
+					core.Key("container.id").String("abc123"),
+				),
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					"cloud.provider":               "aws",
+					"container.id":                 "abc123",
+				},
+			},
+		},
+		{
+			testname: "span with instrumentation library",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+				InstrumentationLibrary: instrumentation.Library{
+					Name:    "myTracer",
+					Version: "v1.2.3",
+				},
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					instrumentationNameAttrKey:     "myTracer",
+					instrumentationVersionAttrKey:  "v1.2.3",
+				},
+			},
+		},
+		{
+			testname: "span with empty instrumentation library omits attributes",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+				},
+			},
+		},
+		{
+			testname: "span with dropped counts",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime:             now,
+				EndTime:               now.Add(2 * time.Second),
+				Name:                  "mySpan",
+				DroppedAttributeCount: 1,
+				DroppedEventCount:     2,
+				DroppedLinkCount:      3,
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+					droppedAttributesCountAttrKey:  1,
+					droppedEventsCountAttrKey:      2,
+					droppedLinksCountAttrKey:       3,
+				},
+			},
+		},
+		{
+			testname: "span with zero dropped counts omits attributes",
+			input: SpanStub{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleSpanID,
+				},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
+			},
+			expect: telemetry.Span{
+				Name:        "mySpan",
+				ID:          sampleSpanIDString,
+				TraceID:     sampleTraceIDString,
+				Timestamp:   now,
+				Duration:    2 * time.Second,
+				ServiceName: service,
+				Attributes: map[string]interface{}{
+					instrumentationProviderAttrKey: instrumentationProviderAttrValue,
+					collectorNameAttrKey:           collectorNameAttrValue,
+				},
+			},
+		},
 		{
 			testname: "span with attributes and error",
-			input: &trace.SpanData{
+			input: SpanStub{
 				SpanContext: core.SpanContext{
 					TraceID: sampleTraceID,
 					SpanID:  sampleSpanID,
 				},
-				StatusCode:    codes.ResourceExhausted,
-				StatusMessage: "ResourceExhausted",
-				StartTime:     now,
-				EndTime:       now.Add(2 * time.Second),
-				Name:          "mySpan",
+				Status:    Status{Code: codes.ResourceExhausted, Message: "ResourceExhausted"},
+				StartTime: now,
+				EndTime:   now.Add(2 * time.Second),
+				Name:      "mySpan",
 				Attributes: []core.KeyValue{
 					core.Key("x0").Bool(true),
 				},
@@ -192,8 +561,188 @@ func TestTransformSpans(t *testing.T) {
 		},
 	}
 	for _, tc := range testcases {
-		if got := Span(service, tc.input); !reflect.DeepEqual(got, tc.expect) {
+		if got := Span(service, tc.input.Snapshot()); !reflect.DeepEqual(got, tc.expect) {
 			t.Errorf("%s: %#v != %#v", tc.testname, got, tc.expect)
 		}
 	}
 }
+
+// TestSpanDataAdapter confirms the deprecated *trace.SpanData adapter still
+// produces the same telemetry.Span as an equivalent SpanStub, for callers
+// migrating off of trace.SpanData during the deprecation window.
+func TestSpanDataAdapter(t *testing.T) {
+	now := time.Now()
+	sd := &trace.SpanData{
+		SpanContext: core.SpanContext{
+			TraceID: sampleTraceID,
+			SpanID:  sampleSpanID,
+		},
+		ParentSpanID: sampleParentID,
+		SpanKind:     apitrace.SpanKindServer,
+		StartTime:    now,
+		EndTime:      now.Add(2 * time.Second),
+		Name:         "mySpan",
+		Attributes: []core.KeyValue{
+			core.Key("x0").Bool(true),
+		},
+		MessageEvents: []trace.Event{
+			{
+				Name: "evt1",
+				Time: now,
+				Attributes: []core.KeyValue{
+					core.Key("k1").String("v1"),
+				},
+			},
+		},
+		Links: []trace.Link{
+			{
+				SpanContext: core.SpanContext{
+					TraceID: sampleTraceID,
+					SpanID:  sampleParentID,
+				},
+				Attributes: []core.KeyValue{
+					core.Key("k1").Bool(true),
+				},
+			},
+		},
+		StatusCode:    codes.ResourceExhausted,
+		StatusMessage: "ResourceExhausted",
+		InstrumentationLibrary: instrumentation.Library{
+			Name:    "myTracer",
+			Version: "v1.2.3",
+		},
+		Resource: resource.New(
+			core.Key("cloud.provider").String("aws"),
+		),
+		DroppedAttributeCount:    1,
+		DroppedMessageEventCount: 2,
+		DroppedLinkCount:         3,
+	}
+
+	got := Span(service, SpanData(sd))
+	want := Span(service, SpanStub{
+		SpanContext:            sd.SpanContext,
+		ParentSpanID:           sd.ParentSpanID,
+		SpanKind:               sd.SpanKind,
+		StartTime:              sd.StartTime,
+		EndTime:                sd.EndTime,
+		Name:                   sd.Name,
+		Attributes:             sd.Attributes,
+		MessageEvents:          sd.MessageEvents,
+		Links:                  sd.Links,
+		Status:                 Status{Code: sd.StatusCode, Message: sd.StatusMessage},
+		InstrumentationLibrary: sd.InstrumentationLibrary,
+		Resource:               sd.Resource,
+		DroppedAttributeCount:  sd.DroppedAttributeCount,
+		DroppedEventCount:      sd.DroppedMessageEventCount,
+		DroppedLinkCount:       sd.DroppedLinkCount,
+	}.Snapshot())
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SpanData(sd) != equivalent SpanStub: %#v != %#v", got, want)
+	}
+}
+
+func TestSpans(t *testing.T) {
+	now := time.Now()
+	good := &trace.SpanData{
+		SpanContext: core.SpanContext{
+			TraceID: sampleTraceID,
+			SpanID:  sampleSpanID,
+		},
+		StartTime: now,
+		EndTime:   now.Add(2 * time.Second),
+		Name:      "mySpan",
+	}
+
+	got, errs := Spans(service, []*trace.SpanData{good, nil, good})
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 transformed spans, got %d", len(got))
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for the nil span, got %d: %v", len(errs), errs)
+	}
+
+	want := Span(service, SpanData(good))
+	if !reflect.DeepEqual(got[0], want) || !reflect.DeepEqual(got[1], want) {
+		t.Errorf("Spans output did not match Span output: %#v", got)
+	}
+}
+
+// TestSpansSharesResourceAttributes checks that every span in a batch backed
+// by one shared Resource carries that Resource's attributes.
+func TestSpansSharesResourceAttributes(t *testing.T) {
+	now := time.Now()
+	res := resource.New(core.Key("cloud.provider").String("aws"))
+	spans := make([]*trace.SpanData, 2)
+	for i := range spans {
+		spans[i] = &trace.SpanData{
+			SpanContext: core.SpanContext{
+				TraceID: sampleTraceID,
+				SpanID:  sampleSpanID,
+			},
+			StartTime: now,
+			EndTime:   now.Add(2 * time.Second),
+			Name:      "mySpan",
+			Resource:  res,
+		}
+	}
+
+	got, errs := Spans(service, spans)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for _, span := range got {
+		if span.Attributes["cloud.provider"] != "aws" {
+			t.Errorf("expected cloud.provider to be carried over, got %#v", span.Attributes)
+		}
+	}
+}
+
+func benchmarkSpanData(n int) []*trace.SpanData {
+	now := time.Now()
+	res := resource.New(core.Key("cloud.provider").String("aws"))
+	spans := make([]*trace.SpanData, n)
+	for i := range spans {
+		spans[i] = &trace.SpanData{
+			SpanContext: core.SpanContext{
+				TraceID: sampleTraceID,
+				SpanID:  sampleSpanID,
+			},
+			StartTime: now,
+			EndTime:   now.Add(2 * time.Second),
+			Name:      "mySpan",
+			Resource:  res,
+			Attributes: []core.KeyValue{
+				core.Key("x0").Bool(true),
+			},
+		}
+	}
+	return spans
+}
+
+// BenchmarkSpanLoop measures the previous call pattern of invoking Span once
+// per span, recomputing resource attributes on every iteration.
+func BenchmarkSpanLoop(b *testing.B) {
+	spans := benchmarkSpanData(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := make([]telemetry.Span, 0, len(spans))
+		for _, sd := range spans {
+			out = append(out, Span(service, SpanData(sd)))
+		}
+	}
+}
+
+// BenchmarkSpans measures the batch path, which shares resource attributes
+// across spans backed by the same Resource.
+func BenchmarkSpans(b *testing.B) {
+	spans := benchmarkSpanData(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Spans(service, spans)
+	}
+}